@@ -0,0 +1,156 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/win777c/gorm/cache"
+)
+
+// defaultCacher is the process-wide second-level query cache used by
+// chains that call Cache(true).
+var defaultCacher cache.Cacher
+
+// SetDefaultCacher installs c as the default Cacher used by queries whose
+// destination is a slice (such as Find) when a chain has caching enabled.
+// Queries into a single struct, as First and Take usually are, are neither
+// served from nor stored in the cache. Passing nil disables the
+// second-level cache process-wide.
+func SetDefaultCacher(c cache.Cacher) {
+	defaultCacher = c
+}
+
+// Cache enables or disables the second-level query cache for this chain.
+// Like Where and the other chain methods, it returns a cloned *DB so it
+// composes with the rest of the query builder.
+func (s *DB) Cache(enable bool) *DB {
+	return s.Set("gorm:cache_enabled", enable)
+}
+
+// cacherForScope returns the Cacher to use for scope's query, or nil if
+// caching isn't enabled for this chain or no default cacher was set.
+func cacherForScope(scope *Scope) cache.Cacher {
+	enabled, ok := scope.Get("gorm:cache_enabled")
+	if !ok || !enabled.(bool) || defaultCacher == nil {
+		return nil
+	}
+	return defaultCacher
+}
+
+func init() {
+	DefaultCallback.Query().Before("gorm:query").Register("gorm:cache_query", cacheQueryCallback)
+	DefaultCallback.Query().After("gorm:query").Register("gorm:cache_populate", cachePopulateCallback)
+	DefaultCallback.Create().After("gorm:after_create").Register("gorm:cache_invalidate_create", cacheInvalidateCallback)
+	DefaultCallback.Update().After("gorm:after_update").Register("gorm:cache_invalidate_update", cacheInvalidateCallback)
+	DefaultCallback.Delete().After("gorm:after_delete").Register("gorm:cache_invalidate_delete", cacheInvalidateCallback)
+}
+
+// cacheQueryCallback looks up the rendered SQL's id list in the cache, then
+// hydrates each bean from the bean cache, scanning them back into
+// scope.Value's destination slice via reflection. It falls back to the
+// normal query callback, unchanged, on any miss: no cached id list, a
+// missing bean, a non-slice destination, or a bean whose type doesn't
+// match the destination's element type.
+func cacheQueryCallback(scope *Scope) {
+	cacher := cacherForScope(scope)
+	if cacher == nil || scope.HasError() {
+		return
+	}
+
+	destination := scope.IndirectValue()
+	if destination.Kind() != reflect.Slice {
+		return
+	}
+
+	// Before("gorm:query") runs ahead of the real query callback, which is
+	// what renders scope.SQL/scope.SQLVars (see scope.prepareQuerySQL). Key
+	// the lookup off the same rendered SQL cachePopulateCallback stores
+	// under, rather than the empty string scope.SQL still holds here.
+	scope.prepareQuerySQL()
+	if scope.HasError() {
+		return
+	}
+
+	tableName := scope.TableName()
+	ids, ok := cacher.GetIds(tableName, scope.SQL).([]string)
+	if !ok {
+		return
+	}
+
+	elemType := destination.Type().Elem()
+	beans := reflect.MakeSlice(destination.Type(), 0, len(ids))
+	for _, id := range ids {
+		bean := cacher.GetBean(tableName, id)
+		if bean == nil {
+			return // partial miss, let the normal query callback run
+		}
+
+		beanValue := reflect.ValueOf(bean)
+		if elemType.Kind() != reflect.Ptr && beanValue.Kind() == reflect.Ptr {
+			beanValue = beanValue.Elem()
+		}
+		if !beanValue.Type().AssignableTo(elemType) {
+			return
+		}
+		beans = reflect.Append(beans, beanValue)
+	}
+
+	destination.Set(beans)
+	scope.SkipLeft()
+}
+
+// cachePopulateCallback runs after a real query and stores the rendered
+// SQL's id list plus each returned bean, so the next identical query can
+// be served from cache.
+func cachePopulateCallback(scope *Scope) {
+	cacher := cacherForScope(scope)
+	if cacher == nil || scope.HasError() {
+		return
+	}
+
+	destination := scope.IndirectValue()
+	if destination.Kind() != reflect.Slice {
+		return
+	}
+
+	tableName := scope.TableName()
+	ids := make([]string, 0, destination.Len())
+	for i := 0; i < destination.Len(); i++ {
+		elem := destination.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			if !elem.CanAddr() {
+				return
+			}
+			elem = elem.Addr()
+		}
+		bean := elem.Interface()
+
+		pk := scope.New(bean).PrimaryKeyValue()
+		if pk == nil {
+			return
+		}
+		id := fmt.Sprintf("%v", pk)
+
+		ids = append(ids, id)
+		cacher.PutBean(tableName, id, bean)
+	}
+	cacher.PutIds(tableName, scope.SQL, ids)
+}
+
+// cacheInvalidateCallback drops the cached id lists and bean for the row
+// scope just created, updated or deleted, so the next read repopulates the
+// cache from the database. It runs whenever a default cacher is set,
+// whether or not the writing chain called Cache(true).
+func cacheInvalidateCallback(scope *Scope) {
+	cacher := defaultCacher
+	if cacher == nil {
+		return
+	}
+
+	tableName := scope.TableName()
+	cacher.ClearIds(tableName)
+
+	if pk := scope.PrimaryKeyValue(); pk != nil {
+		cacher.DelBean(tableName, fmt.Sprintf("%v", pk))
+	}
+}
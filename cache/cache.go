@@ -0,0 +1,25 @@
+// Package cache provides a pluggable second-level query cache for gorm.
+//
+// A Cacher remembers, for a given table, which primary keys a rendered SQL
+// query returned (the "id list") and the decoded bean for each primary key.
+// Callers hydrate a query by first resolving the id list for its SQL, then
+// reading each bean from cache, falling back to the database only on a
+// miss.
+package cache
+
+// Cacher is the second-level cache used by gorm's query callbacks for
+// slice destinations (Find; not First/Take into a single struct) and
+// invalidated by its Create/Update/Delete callbacks.
+type Cacher interface {
+	GetIds(tableName, sql string) interface{}
+	GetBean(tableName, id string) interface{}
+
+	PutIds(tableName, sql string, ids interface{})
+	PutBean(tableName, id string, bean interface{})
+
+	DelIds(tableName, sql string)
+	DelBean(tableName, id string)
+
+	ClearIds(tableName string)
+	ClearBeans(tableName string)
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	kindIds  = "ids"
+	kindBean = "bean"
+)
+
+// lruEntry is the bookkeeping record kept in the eviction list; the actual
+// value lives in the backing Store so eviction never needs to touch it
+// except to delete it.
+type lruEntry struct {
+	key       string
+	tableName string
+	kind      string
+	expiresAt time.Time
+}
+
+// LRUCacher implements Cacher on top of a Store, expiring entries older
+// than ttl and evicting the least-recently-used entry once more than
+// maxElementSize entries are cached. A ttl or maxElementSize of zero
+// disables that limit.
+type LRUCacher struct {
+	store          Store
+	ttl            time.Duration
+	maxElementSize int
+
+	mu       sync.Mutex
+	list     *list.List
+	elements map[string]*list.Element
+}
+
+// NewLRUCacher2 creates an LRUCacher backed by store. The name mirrors
+// xorm's caches package, which reserves NewLRUCacher for a variant with a
+// fixed default store.
+func NewLRUCacher2(store Store, ttl time.Duration, maxElementSize int) *LRUCacher {
+	return &LRUCacher{
+		store:          store,
+		ttl:            ttl,
+		maxElementSize: maxElementSize,
+		list:           list.New(),
+		elements:       map[string]*list.Element{},
+	}
+}
+
+func idsKey(tableName, sql string) string {
+	sum := sha1.Sum([]byte(sql))
+	return fmt.Sprintf("%s:%s", tableName, hex.EncodeToString(sum[:]))
+}
+
+func beanKey(tableName, id string) string {
+	return fmt.Sprintf("%s:%s", tableName, id)
+}
+
+func (c *LRUCacher) GetIds(tableName, sql string) interface{} {
+	return c.get(idsKey(tableName, sql))
+}
+
+func (c *LRUCacher) GetBean(tableName, id string) interface{} {
+	return c.get(beanKey(tableName, id))
+}
+
+func (c *LRUCacher) PutIds(tableName, sql string, ids interface{}) {
+	c.put(idsKey(tableName, sql), tableName, kindIds, ids)
+}
+
+func (c *LRUCacher) PutBean(tableName, id string, bean interface{}) {
+	c.put(beanKey(tableName, id), tableName, kindBean, bean)
+}
+
+func (c *LRUCacher) DelIds(tableName, sql string) {
+	c.del(idsKey(tableName, sql))
+}
+
+func (c *LRUCacher) DelBean(tableName, id string) {
+	c.del(beanKey(tableName, id))
+}
+
+func (c *LRUCacher) ClearIds(tableName string) {
+	c.clearKind(tableName, kindIds)
+}
+
+func (c *LRUCacher) ClearBeans(tableName string) {
+	c.clearKind(tableName, kindBean)
+}
+
+func (c *LRUCacher) get(key string) interface{} {
+	c.mu.Lock()
+	el, ok := c.elements[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil
+	}
+
+	entry := el.Value.(*lruEntry)
+	if c.ttl > 0 && time.Now().After(entry.expiresAt) {
+		c.removeElement(el)
+		c.mu.Unlock()
+		c.store.Del(key)
+		return nil
+	}
+	c.list.MoveToFront(el)
+	c.mu.Unlock()
+
+	value, err := c.store.Get(key)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+func (c *LRUCacher) put(key, tableName, kind string, value interface{}) {
+	c.store.Put(key, value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.elements[key]; ok {
+		el.Value.(*lruEntry).expiresAt = c.expiry()
+		c.list.MoveToFront(el)
+		return
+	}
+
+	el := c.list.PushFront(&lruEntry{key: key, tableName: tableName, kind: kind, expiresAt: c.expiry()})
+	c.elements[key] = el
+
+	if c.maxElementSize > 0 && c.list.Len() > c.maxElementSize {
+		c.evictOldest()
+	}
+}
+
+func (c *LRUCacher) del(key string) {
+	c.mu.Lock()
+	if el, ok := c.elements[key]; ok {
+		c.removeElement(el)
+	}
+	c.mu.Unlock()
+
+	c.store.Del(key)
+}
+
+func (c *LRUCacher) clearKind(tableName, kind string) {
+	c.mu.Lock()
+	var keys []string
+	for el := c.list.Front(); el != nil; {
+		next := el.Next()
+		entry := el.Value.(*lruEntry)
+		if entry.tableName == tableName && entry.kind == kind {
+			keys = append(keys, entry.key)
+			c.removeElement(el)
+		}
+		el = next
+	}
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.store.Del(key)
+	}
+}
+
+func (c *LRUCacher) expiry() time.Time {
+	if c.ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(c.ttl)
+}
+
+// removeElement must be called with c.mu held.
+func (c *LRUCacher) removeElement(el *list.Element) {
+	delete(c.elements, el.Value.(*lruEntry).key)
+	c.list.Remove(el)
+}
+
+// evictOldest must be called with c.mu held.
+func (c *LRUCacher) evictOldest() {
+	el := c.list.Back()
+	if el == nil {
+		return
+	}
+	entry := el.Value.(*lruEntry)
+	c.removeElement(el)
+	c.store.Del(entry.key)
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacherIdsAndBeanHitMiss(t *testing.T) {
+	c := NewLRUCacher2(NewMemoryStore(), 0, 0)
+
+	if got := c.GetIds("users", "SELECT id FROM users"); got != nil {
+		t.Fatalf("expected miss before PutIds, got %v", got)
+	}
+
+	c.PutIds("users", "SELECT id FROM users", []int64{1, 2, 3})
+	got, ok := c.GetIds("users", "SELECT id FROM users").([]int64)
+	if !ok || len(got) != 3 {
+		t.Fatalf("expected cached id list, got %v", got)
+	}
+
+	if got := c.GetBean("users", "1"); got != nil {
+		t.Fatalf("expected bean miss before PutBean, got %v", got)
+	}
+
+	c.PutBean("users", "1", map[string]interface{}{"id": 1, "name": "jinzhu"})
+	bean, ok := c.GetBean("users", "1").(map[string]interface{})
+	if !ok || bean["name"] != "jinzhu" {
+		t.Fatalf("expected cached bean, got %v", bean)
+	}
+}
+
+func TestLRUCacherInvalidation(t *testing.T) {
+	c := NewLRUCacher2(NewMemoryStore(), 0, 0)
+
+	c.PutIds("users", "SELECT id FROM users", []int64{1})
+	c.PutBean("users", "1", "jinzhu")
+	c.PutBean("orders", "1", "order-1")
+
+	c.DelBean("users", "1")
+	if got := c.GetBean("users", "1"); got != nil {
+		t.Fatalf("expected bean evicted after DelBean, got %v", got)
+	}
+
+	c.ClearIds("users")
+	if got := c.GetIds("users", "SELECT id FROM users"); got != nil {
+		t.Fatalf("expected id list evicted after ClearIds, got %v", got)
+	}
+
+	if got := c.GetBean("orders", "1"); got != "order-1" {
+		t.Fatalf("expected unrelated table bean to survive invalidation, got %v", got)
+	}
+}
+
+func TestLRUCacherTTLExpiry(t *testing.T) {
+	c := NewLRUCacher2(NewMemoryStore(), 10*time.Millisecond, 0)
+
+	c.PutBean("users", "1", "jinzhu")
+	if got := c.GetBean("users", "1"); got != "jinzhu" {
+		t.Fatalf("expected fresh entry to hit, got %v", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if got := c.GetBean("users", "1"); got != nil {
+		t.Fatalf("expected expired entry to miss, got %v", got)
+	}
+}
+
+func TestLRUCacherMaxElementSizeEviction(t *testing.T) {
+	c := NewLRUCacher2(NewMemoryStore(), 0, 2)
+
+	c.PutBean("users", "1", "a")
+	c.PutBean("users", "2", "b")
+	c.PutBean("users", "3", "c")
+
+	if got := c.GetBean("users", "1"); got != nil {
+		t.Fatalf("expected oldest entry evicted once over capacity, got %v", got)
+	}
+	if got := c.GetBean("users", "3"); got != "c" {
+		t.Fatalf("expected most recently added entry to remain cached, got %v", got)
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotExist is returned by MemoryStore.Get when key isn't present.
+var ErrNotExist = errors.New("cache: key does not exist")
+
+// MemoryStore is a Store backed by a map guarded by a mutex.
+type MemoryStore struct {
+	mu   sync.RWMutex
+	data map[string]interface{}
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{data: map[string]interface{}{}}
+}
+
+func (s *MemoryStore) Get(key string) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if value, ok := s.data[key]; ok {
+		return value, nil
+	}
+	return nil, ErrNotExist
+}
+
+func (s *MemoryStore) Put(key string, value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = value
+	return nil
+}
+
+func (s *MemoryStore) Del(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	return nil
+}
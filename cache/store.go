@@ -0,0 +1,8 @@
+package cache
+
+// Store is the key/value storage backing a Cacher implementation.
+type Store interface {
+	Get(key string) (interface{}, error)
+	Put(key string, value interface{}) error
+	Del(key string) error
+}
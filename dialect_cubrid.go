@@ -15,6 +15,40 @@ import (
 
 type cubrid struct {
 	commonDialect
+
+	// DBLocation is the location CUBRID's naive DATETIME columns are
+	// assumed to hold; Location is what time.Time values are converted
+	// into for application code. Both default to UTC/Local when unset.
+	DBLocation *time.Location
+	Location   *time.Location
+
+	// splitQueryEnabled, when true, rewrites queries that CUBRID chokes on
+	// (very large IN(...) lists, JOIN+GROUP BY preloads) into a two-step
+	// id-then-rows execution for every chain, without callers needing
+	// db.Set("gorm:cubrid_split_query", true) on each one.
+	splitQueryEnabled   bool
+	splitQueryBatchSize int
+}
+
+// SetLocations configures the location CUBRID's naive DATETIME columns are
+// read in (db) and the location scanned values are converted to for
+// application code (ui). Call it once after opening the connection, e.g.
+// db.Dialect().(*cubrid).SetLocations(time.UTC, time.Local).
+func (s *cubrid) SetLocations(db, ui *time.Location) {
+	s.DBLocation = db
+	s.Location = ui
+}
+
+// SplitQuery enables or disables the split-query rewrite (see
+// dialect_cubrid_split_query.go) for every chain using this dialect,
+// chunking the second-step IN(...) query into groups of batchSize rows. A
+// batchSize <= 0 leaves the batch size as it was: the one set by an earlier
+// call, or cubridSplitQueryBatchSize (500) if none was.
+func (s *cubrid) SplitQuery(enable bool, batchSize int) {
+	s.splitQueryEnabled = enable
+	if batchSize > 0 {
+		s.splitQueryBatchSize = batchSize
+	}
 }
 
 func init() {
@@ -107,6 +141,11 @@ func (s *cubrid) DataTypeOf(field *StructField) string {
 				} else {
 					sqlType = fmt.Sprintf("DATETIME%v NULL", precision)
 				}
+
+				// CUBRID's DATETIME has no timezone of its own; tag the
+				// field so scanTime knows to interpret it as DBLocation
+				// rather than trusting whatever zone the driver attaches.
+				field.TagSettingsSet("CUBRID_NAIVE_DATETIME", "1")
 			}
 		default:
 			if IsByteArrayOrSlice(dataValue) {
@@ -297,3 +336,83 @@ var ParseFieldStructForDialectCUBRID = func(field *StructField, dialect Dialect)
 
 	return fieldValue, dataType, size, strings.TrimSpace(additionalType)
 }
+
+// cubridDateTimeSentinel is the zero-date CUBRID/MySQL drivers sometimes
+// return for an uninitialized DATETIME column instead of NULL.
+const cubridDateTimeSentinel = "0000-00-00 00:00:00"
+
+// scanTime interprets src — []byte, string, time.Time or sql.NullInt64 (a
+// unix timestamp, as some CUBRID drivers represent DATETIME) — as a naive
+// value in s.DBLocation and returns it converted to s.Location, ready to
+// assign to field. The zero sentinel and NULL both scan to a zero
+// time.Time rather than erroring.
+func (s *cubrid) scanTime(src interface{}, field *StructField) (time.Time, error) {
+	dbLocation := s.DBLocation
+	if dbLocation == nil {
+		dbLocation = time.UTC
+	}
+	location := s.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		// The driver's own location on v can't be trusted for a naive
+		// CUBRID DATETIME: reinterpret its wall-clock components as
+		// dbLocation before converting to location.
+		wallClock := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), dbLocation)
+		return wallClock.In(location), nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	case sql.NullInt64:
+		if !v.Valid || v.Int64 == 0 {
+			return time.Time{}, nil
+		}
+		return time.Unix(v.Int64, 0).In(location), nil
+	default:
+		return time.Time{}, fmt.Errorf("cubrid: scanTime can't handle %T for field %s", src, field.Name)
+	}
+
+	if raw == "" || raw == cubridDateTimeSentinel {
+		return time.Time{}, nil
+	}
+
+	layout := "2006-01-02 15:04:05"
+	if len(raw) > len(layout) {
+		layout += "." + strings.Repeat("9", len(raw)-len(layout)-1)
+	}
+
+	parsed, err := time.ParseInLocation(layout, raw, dbLocation)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parsed.In(location), nil
+}
+
+// timeForWrite converts t, assumed to already be in s.Location, into
+// s.DBLocation before it's bound as an INSERT/UPDATE value, so the naive
+// DATETIME CUBRID stores reads back as the same wall-clock time it was
+// given.
+func (s *cubrid) timeForWrite(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	dbLocation := s.DBLocation
+	if dbLocation == nil {
+		dbLocation = time.UTC
+	}
+	return t.In(dbLocation)
+}
+
+// EmptyScanner discards a scanned column without allocating anywhere to
+// put it, for result columns that aren't mapped to any struct field.
+type EmptyScanner struct{}
+
+// Scan implements sql.Scanner by discarding src.
+func (EmptyScanner) Scan(interface{}) error { return nil }
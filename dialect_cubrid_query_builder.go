@@ -0,0 +1,174 @@
+package gorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterQueryBuilder("cubrid", func() QueryBuilder { return &cubridQueryBuilder{} })
+}
+
+type cubridJoin struct {
+	kind, table, on string
+}
+
+// cubridQueryBuilder emits CUBRID-legal SQL: backtick-quoted identifiers,
+// LIMIT n OFFSET m (see cubrid.LimitAndOffsetSQL), FROM db_root for dummy
+// selects with no table, and ROWNUM left unquoted as CUBRID's pseudo-column
+// rather than treated as an identifier.
+type cubridQueryBuilder struct {
+	selectCols []string
+	table      string
+	alias      string
+	joins      []cubridJoin
+	where      []string
+	groupBy    []string
+	having     []string
+	orderBy    []string
+	limit      *int
+	offset     *int
+
+	// Args() must return bind vars in the same order their "?" placeholders
+	// appear in String()'s output, which is SELECT (subqueries), then
+	// WHERE, then HAVING - not the order Select/Where/Having were called
+	// in. Track each clause's args separately and concatenate them in
+	// render order instead of one flat slice built at call time.
+	selectArgs []interface{}
+	whereArgs  []interface{}
+	havingArgs []interface{}
+}
+
+func (b *cubridQueryBuilder) quote(column string) string {
+	if column == "ROWNUM" || column == "*" || strings.ContainsAny(column, "(). ") {
+		return column
+	}
+	return fmt.Sprintf("`%s`", column)
+}
+
+func (b *cubridQueryBuilder) Select(columns ...string) QueryBuilder {
+	for _, column := range columns {
+		b.selectCols = append(b.selectCols, b.quote(column))
+	}
+	return b
+}
+
+func (b *cubridQueryBuilder) From(table, alias string) QueryBuilder {
+	b.table, b.alias = table, alias
+	return b
+}
+
+func (b *cubridQueryBuilder) InnerJoin(table, on string) QueryBuilder {
+	b.joins = append(b.joins, cubridJoin{"INNER JOIN", table, on})
+	return b
+}
+
+func (b *cubridQueryBuilder) LeftJoin(table, on string) QueryBuilder {
+	b.joins = append(b.joins, cubridJoin{"LEFT JOIN", table, on})
+	return b
+}
+
+func (b *cubridQueryBuilder) Where(condition string, args ...interface{}) QueryBuilder {
+	b.where = append(b.where, condition)
+	b.whereArgs = append(b.whereArgs, args...)
+	return b
+}
+
+func (b *cubridQueryBuilder) And(condition string, args ...interface{}) QueryBuilder {
+	return b.Where(condition, args...)
+}
+
+func (b *cubridQueryBuilder) Or(condition string, args ...interface{}) QueryBuilder {
+	if len(b.where) == 0 {
+		return b.Where(condition, args...)
+	}
+	b.where[len(b.where)-1] = fmt.Sprintf("(%s) OR (%s)", b.where[len(b.where)-1], condition)
+	b.whereArgs = append(b.whereArgs, args...)
+	return b
+}
+
+func (b *cubridQueryBuilder) GroupBy(columns ...string) QueryBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+	return b
+}
+
+func (b *cubridQueryBuilder) Having(condition string, args ...interface{}) QueryBuilder {
+	b.having = append(b.having, condition)
+	b.havingArgs = append(b.havingArgs, args...)
+	return b
+}
+
+func (b *cubridQueryBuilder) OrderBy(columns ...string) QueryBuilder {
+	b.orderBy = append(b.orderBy, columns...)
+	return b
+}
+
+func (b *cubridQueryBuilder) Limit(n int) QueryBuilder {
+	b.limit = &n
+	return b
+}
+
+func (b *cubridQueryBuilder) Offset(n int) QueryBuilder {
+	b.offset = &n
+	return b
+}
+
+func (b *cubridQueryBuilder) Subquery(alias string, build func(QueryBuilder)) QueryBuilder {
+	sub := &cubridQueryBuilder{}
+	build(sub)
+	b.selectCols = append(b.selectCols, fmt.Sprintf("(%s) AS %s", sub.String(), b.quote(alias)))
+	b.selectArgs = append(b.selectArgs, sub.Args()...)
+	return b
+}
+
+// Args returns bind vars in the same order their "?" placeholders appear
+// in String()'s output: SELECT (subqueries), then WHERE, then HAVING.
+func (b *cubridQueryBuilder) Args() []interface{} {
+	args := make([]interface{}, 0, len(b.selectArgs)+len(b.whereArgs)+len(b.havingArgs))
+	args = append(args, b.selectArgs...)
+	args = append(args, b.whereArgs...)
+	args = append(args, b.havingArgs...)
+	return args
+}
+
+func (b *cubridQueryBuilder) String() string {
+	selectCols := "*"
+	if len(b.selectCols) > 0 {
+		selectCols = strings.Join(b.selectCols, ", ")
+	}
+
+	from := b.table
+	if from == "" {
+		from = (cubrid{}).SelectFromDummyTable()[len("FROM "):]
+	}
+	if b.alias != "" {
+		from = fmt.Sprintf("%s %s", from, b.alias)
+	}
+
+	var sql strings.Builder
+	fmt.Fprintf(&sql, "SELECT %s FROM %s", selectCols, from)
+
+	for _, join := range b.joins {
+		fmt.Fprintf(&sql, " %s %s ON %s", join.kind, join.table, join.on)
+	}
+	if len(b.where) > 0 {
+		fmt.Fprintf(&sql, " WHERE %s", strings.Join(b.where, " AND "))
+	}
+	if len(b.groupBy) > 0 {
+		fmt.Fprintf(&sql, " GROUP BY %s", strings.Join(b.groupBy, ", "))
+	}
+	if len(b.having) > 0 {
+		fmt.Fprintf(&sql, " HAVING %s", strings.Join(b.having, " AND "))
+	}
+	if len(b.orderBy) > 0 {
+		fmt.Fprintf(&sql, " ORDER BY %s", strings.Join(b.orderBy, ", "))
+	}
+	if b.limit != nil {
+		fmt.Fprintf(&sql, " LIMIT %d", *b.limit)
+		if b.offset != nil {
+			fmt.Fprintf(&sql, " OFFSET %d", *b.offset)
+		}
+	}
+
+	return sql.String()
+}
@@ -0,0 +1,80 @@
+package gorm
+
+import "testing"
+
+func TestCubridQueryBuilderBasicSelect(t *testing.T) {
+	qb, err := NewQueryBuilder("cubrid")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	qb.Select("id", "name").From("users", "u").Where("u.active = ?", true).OrderBy("u.id").Limit(10).Offset(20)
+
+	want := "SELECT `id`, `name` FROM users u WHERE u.active = ? ORDER BY u.id LIMIT 10 OFFSET 20"
+	if got := qb.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	if len(qb.Args()) != 1 || qb.Args()[0] != true {
+		t.Fatalf("unexpected args: %v", qb.Args())
+	}
+}
+
+func TestCubridQueryBuilderJoinsAndGroupBy(t *testing.T) {
+	qb, _ := NewQueryBuilder("cubrid")
+	qb.Select("u.id").From("users", "u").
+		InnerJoin("orders o", "o.user_id = u.id").
+		GroupBy("u.id").
+		Having("COUNT(o.id) > ?", 1)
+
+	want := "SELECT u.id FROM users u INNER JOIN orders o ON o.user_id = u.id GROUP BY u.id HAVING COUNT(o.id) > ?"
+	if got := qb.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCubridQueryBuilderDummyFrom(t *testing.T) {
+	qb, _ := NewQueryBuilder("cubrid")
+	qb.Select("1")
+
+	want := "SELECT `1` FROM db_root"
+	if got := qb.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCubridQueryBuilderRowNum(t *testing.T) {
+	qb, _ := NewQueryBuilder("cubrid")
+	qb.Select("ROWNUM", "id").From("users", "")
+
+	want := "SELECT ROWNUM, `id` FROM users"
+	if got := qb.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCubridQueryBuilderSubqueryArgOrderMatchesCallOrderIndependence(t *testing.T) {
+	qb, _ := NewQueryBuilder("cubrid")
+	qb.Select("u.id").From("users", "u").
+		Where("u.active = ?", true).
+		Subquery("order_count", func(sub QueryBuilder) {
+			sub.Select("COUNT(*)").From("orders", "").Where("orders.user_id = u.id AND orders.status = ?", "paid")
+		})
+
+	want := "SELECT u.id, (SELECT COUNT(*) FROM orders WHERE orders.user_id = u.id AND orders.status = ?) AS `order_count` FROM users u WHERE u.active = ?"
+	if got := qb.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+
+	// The subquery's "?" renders before WHERE's in String(), even though
+	// Where was called before Subquery, so Args() must put "paid" first.
+	args := qb.Args()
+	if len(args) != 2 || args[0] != "paid" || args[1] != true {
+		t.Fatalf("Args() = %v, want [paid true] (render order, not call order)", args)
+	}
+}
+
+func TestNewQueryBuilderUnknownDialect(t *testing.T) {
+	if _, err := NewQueryBuilder("does-not-exist"); err == nil {
+		t.Fatalf("expected an error for an unregistered dialect")
+	}
+}
@@ -0,0 +1,194 @@
+package gorm
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// cubridSplitQueryBatchSize is the default number of primary keys sent per
+// IN (...) chunk once a query has been split.
+const cubridSplitQueryBatchSize = 500
+
+// cubridJoinGroupByQuery recognizes the SELECT tbl.* ... JOIN ... GROUP BY
+// tbl.id ORDER BY ... shape gorm emits for preloads and polymorphic
+// associations, which CUBRID (like MSSQL) can choke on.
+var cubridJoinGroupByQuery = regexp.MustCompile(`(?is)^SELECT\s+.+\s+FROM\s+.+\bJOIN\b.+\bGROUP BY\b`)
+
+// cubridLargeInList recognizes an IN (...) list with more placeholders
+// than fit comfortably under CUBRID's bind-parameter cap, i.e. more than
+// cubridSplitQueryBatchSize (500).
+var cubridLargeInList = regexp.MustCompile(`(?is)\bIN\s*\(\s*\?(\s*,\s*\?){500,}\s*\)`)
+
+// needsSplitQuery reports whether sql is one of the shapes that should be
+// rewritten into a two-step id-then-rows execution instead of being run
+// as-is.
+func needsSplitQuery(sql string) bool {
+	return cubridJoinGroupByQuery.MatchString(sql) || cubridLargeInList.MatchString(sql)
+}
+
+// primaryKeyOnlySelect rewrites a SELECT so that it projects only
+// pkColumn, keeping every other clause (FROM/JOIN/WHERE/GROUP BY/ORDER
+// BY/LIMIT) untouched. This is the first step of the split: fetch the
+// primary keys the full query would have returned, cheaply.
+func primaryKeyOnlySelect(sql, pkColumn string) (string, error) {
+	from := strings.Index(strings.ToUpper(sql), "FROM ")
+	if from < 0 {
+		return "", fmt.Errorf("gorm: cubrid split query: no FROM clause in %q", sql)
+	}
+	return fmt.Sprintf("SELECT %s %s", pkColumn, sql[from:]), nil
+}
+
+// chunkValues splits ids into groups of at most batchSize, so the second
+// step's IN (...) clause stays within CUBRID's statement-length and
+// bind-parameter limits. A batchSize <= 0 falls back to
+// cubridSplitQueryBatchSize.
+func chunkValues(ids []interface{}, batchSize int) [][]interface{} {
+	if batchSize <= 0 {
+		batchSize = cubridSplitQueryBatchSize
+	}
+
+	var chunks [][]interface{}
+	for len(ids) > 0 {
+		n := batchSize
+		if n > len(ids) {
+			n = len(ids)
+		}
+		chunks = append(chunks, ids[:n])
+		ids = ids[n:]
+	}
+	return chunks
+}
+
+// inClauseSQL builds `SELECT * FROM tableName WHERE pkColumn IN (?, ?, ...)`
+// for one chunk of primary keys, returning the bind vars alongside it.
+func inClauseSQL(tableName, pkColumn string, chunk []interface{}) (string, []interface{}) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+	sql := fmt.Sprintf("SELECT * FROM %s WHERE %s IN (%s)", tableName, pkColumn, placeholders)
+	return sql, chunk
+}
+
+// elementPrimaryKeyValue returns elem's primary key value by asking scope
+// to build a throwaway scope for it, the same indirection gorm uses
+// elsewhere to inspect an arbitrary row's fields by reflection. Reading
+// the value back off the decoded struct, rather than keying rows by the
+// SQL column name, sidesteps any mismatch between a qualified pkColumn
+// used in the first step's SELECT and the unqualified column names a
+// second step's SELECT * returns.
+func elementPrimaryKeyValue(scope *Scope, elem reflect.Value) interface{} {
+	if elem.Kind() != reflect.Ptr {
+		if !elem.CanAddr() {
+			return nil
+		}
+		elem = elem.Addr()
+	}
+	return scope.New(elem.Interface()).PrimaryKeyValue()
+}
+
+// cubridSplitQueryCallback rewrites a qualifying SELECT into the two-step
+// execution described above: fetch the primary keys the JOIN/WHERE/GROUP
+// BY/ORDER BY/LIMIT clauses would have returned, then re-select full rows
+// in batches of the dialect's configured size, scanning each batch
+// directly into scope.Value's destination type and restoring the original
+// id order client-side.
+func cubridSplitQueryCallback(scope *Scope) {
+	dialect := cubridDialectOf(scope)
+	if dialect == nil || scope.HasError() {
+		return
+	}
+
+	enabled, _ := scope.Get("gorm:cubrid_split_query")
+	if !dialect.splitQueryEnabled && enabled != true {
+		return
+	}
+
+	// Before("gorm:query") runs ahead of the real query callback, which is
+	// what renders scope.SQL/scope.SQLVars (see scope.prepareQuerySQL).
+	// Render it ourselves so the shape check below sees the real query
+	// instead of the empty string scope.SQL still holds here.
+	scope.prepareQuerySQL()
+	if scope.HasError() {
+		return
+	}
+
+	if !needsSplitQuery(scope.SQL) {
+		return
+	}
+
+	destination := scope.IndirectValue()
+	if destination.Kind() != reflect.Slice {
+		return // the split-query rewrite only applies to Find's slice destinations
+	}
+
+	tableName := scope.TableName()
+	pkColumn := scope.PrimaryKey()
+	if cubridJoinGroupByQuery.MatchString(scope.SQL) {
+		// A bare pk column is ambiguous once the rewritten SELECT still
+		// carries the original JOIN - qualify it with the driving table so
+		// "SELECT id FROM users JOIN orders ..." doesn't become
+		// "column 'id' is ambiguous".
+		pkColumn = fmt.Sprintf("%s.%s", tableName, pkColumn)
+	}
+
+	idSQL, err := primaryKeyOnlySelect(scope.SQL, pkColumn)
+	if err != nil {
+		scope.Err(err)
+		return
+	}
+
+	idRows, err := scope.NewDB().Raw(idSQL, scope.SQLVars...).Rows()
+	if err != nil {
+		scope.Err(err)
+		return
+	}
+	var ids []interface{}
+	for idRows.Next() {
+		var id interface{}
+		if err := idRows.Scan(&id); err != nil {
+			idRows.Close()
+			scope.Err(err)
+			return
+		}
+		ids = append(ids, id)
+	}
+	idRows.Close()
+
+	if len(ids) == 0 {
+		destination.Set(reflect.MakeSlice(destination.Type(), 0, 0))
+		scope.SkipLeft()
+		return
+	}
+
+	rowsByID := make(map[string]reflect.Value, len(ids))
+	for _, chunk := range chunkValues(ids, dialect.splitQueryBatchSize) {
+		chunkSQL, chunkVars := inClauseSQL(tableName, pkColumn, chunk)
+
+		chunkDestination := reflect.New(destination.Type())
+		if err := scope.NewDB().Raw(chunkSQL, chunkVars...).Scan(chunkDestination.Interface()).Error; err != nil {
+			scope.Err(err)
+			return
+		}
+
+		chunkSlice := chunkDestination.Elem()
+		for i := 0; i < chunkSlice.Len(); i++ {
+			elem := chunkSlice.Index(i)
+			pk := elementPrimaryKeyValue(scope, elem)
+			rowsByID[fmt.Sprintf("%v", pk)] = elem
+		}
+	}
+
+	ordered := reflect.MakeSlice(destination.Type(), 0, len(ids))
+	for _, id := range ids {
+		if elem, ok := rowsByID[fmt.Sprintf("%v", id)]; ok {
+			ordered = reflect.Append(ordered, elem)
+		}
+	}
+
+	destination.Set(ordered)
+	scope.SkipLeft()
+}
+
+func init() {
+	DefaultCallback.Query().Before("gorm:query").Register("gorm:cubrid_split_query", cubridSplitQueryCallback)
+}
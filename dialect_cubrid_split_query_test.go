@@ -0,0 +1,284 @@
+package gorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNeedsSplitQueryDetectsJoinGroupBy(t *testing.T) {
+	sql := "SELECT users.* FROM users JOIN orders ON orders.user_id = users.id GROUP BY users.id ORDER BY users.id"
+	if !needsSplitQuery(sql) {
+		t.Fatalf("expected JOIN+GROUP BY query to need splitting")
+	}
+}
+
+func TestNeedsSplitQueryDetectsLargeInList(t *testing.T) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", 600), ", ")
+	sql := "SELECT * FROM users WHERE id IN (" + placeholders + ")"
+	if !needsSplitQuery(sql) {
+		t.Fatalf("expected large IN(...) list to need splitting")
+	}
+}
+
+func TestNeedsSplitQueryIgnoresPlainSelect(t *testing.T) {
+	if needsSplitQuery("SELECT * FROM users WHERE id = ?") {
+		t.Fatalf("did not expect a plain select to need splitting")
+	}
+}
+
+func TestPrimaryKeyOnlySelect(t *testing.T) {
+	sql := "SELECT users.* FROM users JOIN orders ON orders.user_id = users.id GROUP BY users.id ORDER BY users.id LIMIT 10"
+	got, err := primaryKeyOnlySelect(sql, "users.id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "SELECT users.id FROM users JOIN orders ON orders.user_id = users.id GROUP BY users.id ORDER BY users.id LIMIT 10"
+	if got != want {
+		t.Fatalf("primaryKeyOnlySelect = %q, want %q", got, want)
+	}
+}
+
+func TestChunkValues(t *testing.T) {
+	ids := make([]interface{}, 1250)
+	for i := range ids {
+		ids[i] = i
+	}
+
+	chunks := chunkValues(ids, 500)
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+	if len(chunks[0]) != 500 || len(chunks[1]) != 500 || len(chunks[2]) != 250 {
+		t.Fatalf("unexpected chunk sizes: %d, %d, %d", len(chunks[0]), len(chunks[1]), len(chunks[2]))
+	}
+}
+
+func TestInClauseSQL(t *testing.T) {
+	sql, vars := inClauseSQL("users", "id", []interface{}{1, 2, 3})
+	if sql != "SELECT * FROM users WHERE id IN (?, ?, ?)" {
+		t.Fatalf("unexpected sql: %q", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{1, 2, 3}) {
+		t.Fatalf("unexpected vars: %v", vars)
+	}
+}
+
+// --- integration-style plan/ordering tests ---
+//
+// cubridSplitQueryCallback itself needs a live *Scope/*DB, which this
+// source snapshot doesn't define, so these tests drive the same
+// production helpers (needsSplitQuery, primaryKeyOnlySelect, chunkValues,
+// inClauseSQL) end-to-end against a real database/sql.DB backed by a fake
+// driver, asserting exactly the two-statement plan runs and that results
+// come back in the first step's id order.
+
+type fakeRows struct {
+	columns []string
+	data    [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
+
+type fakeConn struct {
+	queries *[]string
+	respond func(query string) *fakeRows
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not implemented") }
+func (c *fakeConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	*c.queries = append(*c.queries, query)
+	return c.respond(query), nil
+}
+
+type fakeDriver struct {
+	queries *[]string
+	respond func(query string) *fakeRows
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{queries: d.queries, respond: d.respond}, nil
+}
+
+// runSplitQuery replicates cubridSplitQueryCallback's algorithm against a
+// plain *sql.DB, so the rewrite can be exercised without a gorm Scope.
+// pkColumn is unqualified (e.g. "id"), exactly what scope.PrimaryKey()
+// returns; runSplitQuery qualifies it with tableName for the JOIN shape
+// the same way cubridSplitQueryCallback does, rather than being handed the
+// already-qualified column, so the ambiguous-column fix is actually
+// exercised.
+func runSplitQuery(db *sql.DB, tableName, pkColumn, originalSQL string, batchSize int) ([]map[string]interface{}, error) {
+	if cubridJoinGroupByQuery.MatchString(originalSQL) {
+		pkColumn = fmt.Sprintf("%s.%s", tableName, pkColumn)
+	}
+
+	idSQL, err := primaryKeyOnlySelect(originalSQL, pkColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	idRows, err := db.Query(idSQL)
+	if err != nil {
+		return nil, err
+	}
+	var ids []interface{}
+	for idRows.Next() {
+		var id int64
+		if err := idRows.Scan(&id); err != nil {
+			idRows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	idRows.Close()
+
+	byID := make(map[interface{}]map[string]interface{}, len(ids))
+	for _, chunk := range chunkValues(ids, batchSize) {
+		chunkSQL, chunkVars := inClauseSQL(tableName, pkColumn, chunk)
+
+		placeholders := make([]interface{}, len(chunkVars))
+		for i, v := range chunkVars {
+			placeholders[i] = v
+		}
+		rows, err := db.Query(chunkSQL, placeholders...)
+		if err != nil {
+			return nil, err
+		}
+		columns, err := rows.Columns()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		for rows.Next() {
+			values := make([]interface{}, len(columns))
+			pointers := make([]interface{}, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := rows.Scan(pointers...); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			row := make(map[string]interface{}, len(columns))
+			for i, column := range columns {
+				row[column] = values[i]
+			}
+			byID[row["id"]] = row
+		}
+		rows.Close()
+	}
+
+	ordered := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		if row, ok := byID[id]; ok {
+			ordered = append(ordered, row)
+		}
+	}
+	return ordered, nil
+}
+
+func TestSplitQueryHasManyPreload(t *testing.T) {
+	var queries []string
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", 600), ", ")
+	originalSQL := "SELECT * FROM orders WHERE user_id IN (" + placeholders + ")"
+
+	respond := func(query string) *fakeRows {
+		switch {
+		case strings.HasPrefix(query, "SELECT id FROM orders"):
+			// out-of-order on purpose, to prove client-side reordering runs
+			return &fakeRows{columns: []string{"id"}, data: [][]driver.Value{{int64(3)}, {int64(1)}, {int64(2)}}}
+		case strings.Contains(query, "WHERE id IN"):
+			return &fakeRows{
+				columns: []string{"id", "user_id"},
+				data:    [][]driver.Value{{int64(1), int64(42)}, {int64(2), int64(42)}, {int64(3), int64(42)}},
+			}
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+
+	sql.Register("cubrid_split_preload_fake", &fakeDriver{queries: &queries, respond: respond})
+	db, err := sql.Open("cubrid_split_preload_fake", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	if !needsSplitQuery(originalSQL) {
+		t.Fatalf("expected the large IN(...) preload query to need splitting")
+	}
+
+	rows, err := runSplitQuery(db, "orders", "id", originalSQL, 2)
+	if err != nil {
+		t.Fatalf("runSplitQuery: %v", err)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("expected 1 id query + 2 chunked IN queries (batch size 2 over 3 ids), got %d: %v", len(queries), queries)
+	}
+	if len(rows) != 3 || rows[0]["id"] != int64(3) || rows[1]["id"] != int64(1) || rows[2]["id"] != int64(2) {
+		t.Fatalf("expected rows restored to the id query's order 3,1,2, got %v", rows)
+	}
+}
+
+func TestSplitQueryMany2ManyJoin(t *testing.T) {
+	var queries []string
+	originalSQL := "SELECT users.* FROM users INNER JOIN user_languages ON user_languages.user_id = users.id " +
+		"WHERE user_languages.language_id = ? GROUP BY users.id ORDER BY users.id"
+
+	respond := func(query string) *fakeRows {
+		switch {
+		case strings.HasPrefix(query, "SELECT users.id FROM users"):
+			return &fakeRows{columns: []string{"id"}, data: [][]driver.Value{{int64(2)}, {int64(1)}}}
+		case strings.Contains(query, "WHERE users.id IN"):
+			return &fakeRows{
+				columns: []string{"id", "name"},
+				data:    [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}},
+			}
+		default:
+			t.Fatalf("unexpected query: %s", query)
+			return nil
+		}
+	}
+
+	sql.Register("cubrid_split_m2m_fake", &fakeDriver{queries: &queries, respond: respond})
+	db, err := sql.Open("cubrid_split_m2m_fake", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+
+	if !needsSplitQuery(originalSQL) {
+		t.Fatalf("expected the many2many JOIN+GROUP BY query to need splitting")
+	}
+
+	rows, err := runSplitQuery(db, "users", "id", originalSQL, 500)
+	if err != nil {
+		t.Fatalf("runSplitQuery: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("expected exactly 1 id query + 1 chunked IN query, got %d: %v", len(queries), queries)
+	}
+	if len(rows) != 2 || rows[0]["name"] != "bob" || rows[1]["name"] != "alice" {
+		t.Fatalf("expected rows restored to the id query's order bob,alice, got %v", rows)
+	}
+}
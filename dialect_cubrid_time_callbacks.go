@@ -0,0 +1,77 @@
+package gorm
+
+import (
+	"reflect"
+	"time"
+)
+
+func init() {
+	DefaultCallback.Query().After("gorm:query").Register("gorm:cubrid_scan_naive_datetime", cubridScanNaiveDatetimeCallback)
+	DefaultCallback.Create().Before("gorm:before_create").Register("gorm:cubrid_convert_naive_datetime_create", cubridConvertNaiveDatetimeCallback)
+	DefaultCallback.Update().Before("gorm:before_update").Register("gorm:cubrid_convert_naive_datetime_update", cubridConvertNaiveDatetimeCallback)
+}
+
+// cubridDialectOf returns scope's dialect as *cubrid, or nil if the chain
+// isn't using the CUBRID dialect. The naive-DATETIME conversion below is a
+// CUBRID-specific quirk; every other dialect's driver round-trips
+// timezones on its own.
+func cubridDialectOf(scope *Scope) *cubrid {
+	d, _ := scope.db.dialect.(*cubrid)
+	return d
+}
+
+// cubridScanNaiveDatetimeCallback reinterprets every CUBRID_NAIVE_DATETIME
+// tagged time.Time field gorm just scanned as wall-clock time in the
+// dialect's DBLocation, converting it to Location for application code.
+func cubridScanNaiveDatetimeCallback(scope *Scope) {
+	dialect := cubridDialectOf(scope)
+	if dialect == nil || scope.HasError() {
+		return
+	}
+
+	for _, field := range scope.Fields() {
+		if field.IsBlank || !field.Field.IsValid() || !field.Field.CanSet() {
+			continue
+		}
+		if _, ok := field.TagSettingsGet("CUBRID_NAIVE_DATETIME"); !ok {
+			continue
+		}
+
+		t, ok := field.Field.Interface().(time.Time)
+		if !ok {
+			continue
+		}
+
+		converted, err := dialect.scanTime(t, field.StructField)
+		if err != nil {
+			scope.Err(err)
+			return
+		}
+		field.Field.Set(reflect.ValueOf(converted))
+	}
+}
+
+// cubridConvertNaiveDatetimeCallback converts every CUBRID_NAIVE_DATETIME
+// tagged time.Time field from Location back to DBLocation before it's
+// bound into an INSERT/UPDATE statement.
+func cubridConvertNaiveDatetimeCallback(scope *Scope) {
+	dialect := cubridDialectOf(scope)
+	if dialect == nil || scope.HasError() {
+		return
+	}
+
+	for _, field := range scope.Fields() {
+		if field.IsBlank || !field.Field.IsValid() || !field.Field.CanSet() {
+			continue
+		}
+		if _, ok := field.TagSettingsGet("CUBRID_NAIVE_DATETIME"); !ok {
+			continue
+		}
+
+		t, ok := field.Field.Interface().(time.Time)
+		if !ok {
+			continue
+		}
+		field.Field.Set(reflect.ValueOf(dialect.timeForWrite(t)))
+	}
+}
@@ -0,0 +1,76 @@
+package gorm
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCubridScanTimeSentinelAndNullAreZero(t *testing.T) {
+	s := &cubrid{}
+	field := &StructField{Name: "CreatedAt"}
+
+	for _, src := range []interface{}{nil, "0000-00-00 00:00:00", []byte("0000-00-00 00:00:00")} {
+		got, err := s.scanTime(src, field)
+		if err != nil {
+			t.Fatalf("scanTime(%v) returned error: %v", src, err)
+		}
+		if !got.IsZero() {
+			t.Fatalf("scanTime(%v) = %v, want zero time", src, got)
+		}
+	}
+}
+
+func TestCubridScanTimeConvertsBetweenLocations(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	s := &cubrid{DBLocation: time.UTC, Location: la}
+	field := &StructField{Name: "CreatedAt"}
+
+	// 2024-03-10 is the US DST transition: 10:00 UTC is 02:00 PST, which
+	// doesn't exist locally, but la's wall clock for that UTC instant is
+	// well defined and should round-trip without error.
+	got, err := s.scanTime("2024-03-10 10:00:00", field)
+	if err != nil {
+		t.Fatalf("scanTime returned error: %v", err)
+	}
+
+	want := time.Date(2024, 3, 10, 10, 0, 0, 0, time.UTC).In(la)
+	if !got.Equal(want) {
+		t.Fatalf("scanTime = %v, want %v", got, want)
+	}
+
+	back := s.timeForWrite(got)
+	if !back.Equal(want) || back.Location() != time.UTC {
+		t.Fatalf("timeForWrite = %v in %v, want %v in UTC", back, back.Location(), want)
+	}
+}
+
+func TestCubridDataTypeOfDatetimePrecisionRoundTrip(t *testing.T) {
+	s := &cubrid{}
+	field := &StructField{
+		Name:   "CreatedAt",
+		Struct: reflect.StructField{Type: reflect.TypeOf(time.Time{})},
+	}
+	field.TagSettingsSet("PRECISION", "3")
+	field.TagSettingsSet("NOT NULL", "NOT NULL")
+
+	got := s.DataTypeOf(field)
+	if got != "DATETIME(3) NOT NULL" {
+		t.Fatalf("DataTypeOf = %q, want %q", got, "DATETIME(3) NOT NULL")
+	}
+
+	if _, ok := field.TagSettingsGet("CUBRID_NAIVE_DATETIME"); !ok {
+		t.Fatalf("expected DataTypeOf to tag the field as a naive datetime")
+	}
+}
+
+func TestEmptyScannerDiscardsValue(t *testing.T) {
+	var s EmptyScanner
+	if err := s.Scan("anything"); err != nil {
+		t.Fatalf("Scan returned error: %v", err)
+	}
+}
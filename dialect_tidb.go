@@ -0,0 +1,219 @@
+package gorm
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tidb dialect mostly behaves like MySQL/CUBRID but needs a handful of
+// overrides for its distributed storage engine: AUTO_RANDOM primary keys
+// instead of AUTO_INCREMENT, INFORMATION_SCHEMA based introspection, and a
+// copy-swap fallback for MODIFY COLUMN on releases that don't support it
+// in place.
+type tidb struct {
+	commonDialect
+}
+
+func init() {
+	RegisterDialect("tidb", &tidb{})
+}
+
+func (tidb) GetName() string {
+	return "tidb"
+}
+
+func (tidb) Quote(key string) string {
+	return fmt.Sprintf("`%s`", key)
+}
+
+// DataTypeOf falls back to the CUBRID/MySQL compatible type mapping, except
+// for primary keys tagged AUTO_RANDOM, which TiDB uses in place of
+// AUTO_INCREMENT to spread inserts across regions.
+func (s *tidb) DataTypeOf(field *StructField) string {
+	sqlType, additionalType := ParseFieldStructForDialectCUBRID2(field, s)
+
+	if _, ok := field.TagSettingsGet("AUTO_RANDOM"); ok && field.IsPrimaryKey {
+		field.TagSettingsDelete("AUTO_INCREMENT")
+		sqlType = "bigint AUTO_RANDOM"
+	}
+
+	if _, ok := field.TagSettingsGet("SHARD_ROW_ID_BITS"); ok && field.IsPrimaryKey {
+		field.TagSettingsDelete("AUTO_INCREMENT")
+	}
+
+	if strings.TrimSpace(additionalType) == "" {
+		return sqlType
+	}
+	return fmt.Sprintf("%v %v", sqlType, additionalType)
+}
+
+func (s tidb) ModifyColumn(tableName string, columnName string, typ string) error {
+	_, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", tableName, columnName, typ))
+	if err != nil {
+		// Some TiDB releases reject in-place MODIFY COLUMN; fall back to a
+		// copy-swap: add a shadow column, backfill it, drop the old one,
+		// then rename the shadow column into place.
+		shadow := fmt.Sprintf("%s__tidb_tmp", columnName)
+		if _, aerr := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, shadow, typ)); aerr != nil {
+			return err
+		}
+		if _, uerr := s.db.Exec(fmt.Sprintf("UPDATE %s SET %s = %s", tableName, shadow, columnName)); uerr != nil {
+			return err
+		}
+		if _, derr := s.db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", tableName, columnName)); derr != nil {
+			return err
+		}
+		_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s CHANGE %s %s %s", tableName, shadow, columnName, typ))
+	}
+	return err
+}
+
+func (s tidb) HasTable(tableName string) bool {
+	var count int
+	currentDatabase, tableName := currentDatabaseAndTable(&s, tableName)
+	sql := fmt.Sprintf("SELECT count(*) FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'", currentDatabase, tableName)
+	s.db.QueryRow(sql).Scan(&count)
+	return count > 0
+}
+
+func (s tidb) HasIndex(tableName string, indexName string) bool {
+	var count int
+	currentDatabase, tableName := currentDatabaseAndTable(&s, tableName)
+	sql := fmt.Sprintf("SELECT count(*) FROM INFORMATION_SCHEMA.STATISTICS WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s' AND INDEX_NAME = '%s'", currentDatabase, tableName, indexName)
+	s.db.QueryRow(sql).Scan(&count)
+	return count > 0
+}
+
+func (s tidb) HasColumn(tableName string, columnName string) bool {
+	var count int
+	currentDatabase, tableName := currentDatabaseAndTable(&s, tableName)
+	sql := fmt.Sprintf("SELECT count(*) FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s' AND COLUMN_NAME = '%s'", currentDatabase, tableName, columnName)
+	s.db.QueryRow(sql).Scan(&count)
+	return count > 0
+}
+
+func (s tidb) CurrentDatabase() (name string) {
+	s.db.QueryRow("SELECT DATABASE()").Scan(&name)
+	return
+}
+
+// NormalizeIndexAndColumn ignores prefix-length index syntax (col(10)),
+// which TiDB does not support, and returns the column name unchanged.
+func (tidb) NormalizeIndexAndColumn(indexName, columnName string) (string, string) {
+	return indexName, columnName
+}
+
+func (s tidb) BuildKeyName(kind, tableName string, fields ...string) string {
+	keyName := s.commonDialect.BuildKeyName(kind, tableName, fields...)
+	if utf8.RuneCountInString(keyName) <= 64 {
+		return keyName
+	}
+	h := sha1.New()
+	h.Write([]byte(keyName))
+	bs := h.Sum(nil)
+
+	// sha1 is 40 characters, keep first 24 characters of destination
+	destRunes := []rune(keyNameRegex.ReplaceAllString(fields[0], "_"))
+	if len(destRunes) > 24 {
+		destRunes = destRunes[:24]
+	}
+
+	return fmt.Sprintf("%s%x", string(destRunes), bs)
+}
+
+// ParseFieldStructForDialectCUBRID2 mirrors the CUBRID/MySQL type mapping
+// used by ParseFieldStructForDialectCUBRID, except that int8 and uint8 map
+// to int and int unsigned, and it never adds AUTO_INCREMENT to a field
+// tagged AUTO_RANDOM or SHARD_ROW_ID_BITS. DataTypeOf calls it before its
+// own AUTO_RANDOM and SHARD_ROW_ID_BITS checks. It returns the derived
+// sqlType alongside additionalType (NOT NULL/UNIQUE/DEFAULT/COMMENT) so
+// callers can append it the same way cubrid.DataTypeOf does.
+var ParseFieldStructForDialectCUBRID2 = func(field *StructField, dialect Dialect) (string, string) {
+	var dataValue, sqlType, size, additionalType = ParseFieldStructForDialectCUBRID(field, dialect)
+
+	if sqlType != "" {
+		return sqlType, additionalType
+	}
+
+	// A field tagged AUTO_RANDOM or SHARD_ROW_ID_BITS is handled by the
+	// checks in DataTypeOf above (an AUTO_RANDOM primary key becomes
+	// bigint AUTO_RANDOM), so it must not also pick up AUTO_INCREMENT here.
+	_, hasAutoRandom := field.TagSettingsGet("AUTO_RANDOM")
+	_, hasShardRowIDBits := field.TagSettingsGet("SHARD_ROW_ID_BITS")
+	canAutoIncrement := !hasAutoRandom && !hasShardRowIDBits
+	if checker, ok := dialect.(interface {
+		fieldCanAutoIncrement(*StructField) bool
+	}); ok {
+		canAutoIncrement = canAutoIncrement && checker.fieldCanAutoIncrement(field)
+	} else {
+		canAutoIncrement = false
+	}
+
+	switch dataValue.Kind() {
+	case reflect.Bool:
+		sqlType = "boolean"
+	case reflect.Int8, reflect.Int, reflect.Int16, reflect.Int32:
+		if canAutoIncrement {
+			field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+			sqlType = "int AUTO_INCREMENT"
+		} else {
+			sqlType = "int"
+		}
+	case reflect.Uint8, reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uintptr:
+		if canAutoIncrement {
+			field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+			sqlType = "int unsigned AUTO_INCREMENT"
+		} else {
+			sqlType = "int unsigned"
+		}
+	case reflect.Int64:
+		if canAutoIncrement {
+			field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+			sqlType = "bigint AUTO_INCREMENT"
+		} else {
+			sqlType = "bigint"
+		}
+	case reflect.Uint64:
+		if canAutoIncrement {
+			field.TagSettingsSet("AUTO_INCREMENT", "AUTO_INCREMENT")
+			sqlType = "bigint unsigned AUTO_INCREMENT"
+		} else {
+			sqlType = "bigint unsigned"
+		}
+	case reflect.Float32, reflect.Float64:
+		sqlType = "double"
+	case reflect.String:
+		if size > 0 && size < 65532 {
+			sqlType = fmt.Sprintf("varchar(%d)", size)
+		} else {
+			sqlType = "longtext"
+		}
+	case reflect.Struct:
+		if _, ok := dataValue.Interface().(time.Time); ok {
+			precision := ""
+			if p, ok := field.TagSettingsGet("PRECISION"); ok {
+				precision = fmt.Sprintf("(%s)", p)
+			}
+
+			if _, ok := field.TagSettings["NOT NULL"]; ok || field.IsPrimaryKey {
+				sqlType = fmt.Sprintf("DATETIME%v", precision)
+			} else {
+				sqlType = fmt.Sprintf("DATETIME%v NULL", precision)
+			}
+		}
+	default:
+		if IsByteArrayOrSlice(dataValue) {
+			sqlType = fmt.Sprintf("bit varying(%d)", size)
+		}
+	}
+
+	if sqlType == "" {
+		panic(fmt.Sprintf("invalid sql type %s (%s) in field %s for tidb", dataValue.Type().Name(), dataValue.Kind().String(), field.Name))
+	}
+
+	return sqlType, additionalType
+}